@@ -0,0 +1,86 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+//LoadDetectionResults reads a JSON report previously written via json.Marshal(DetectionResults)
+//(the shape documented by SchemaVersion) and returns it as a *DetectionResults suitable for
+//passing to Diff.
+//
+//This is the loading half of a --baseline path/to/results.json CLI flag, NOT the flag itself:
+//cmd/talisman, where that flag would be parsed and the file opened, is not part of this source
+//tree snapshot (it starts at detector/helpers), so the flag can't be wired up here. Until
+//cmd/talisman exists, --baseline is not an option a user can actually pass to the talisman
+//binary; a future cmd/talisman only needs to open the file and hand the reader here.
+func LoadDetectionResults(r io.Reader) (*DetectionResults, error) {
+	var results DetectionResults
+	if err := json.NewDecoder(r).Decode(&results); err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
+
+//fingerprintFor computes the canonical Fingerprint stored on a Details entry. It hashes the
+//RuleID, filename and a whitespace-normalized Message (plus the Location snippet, when known)
+//so that reformatting or unrelated edits elsewhere in a file don't change the fingerprint of a
+//finding that's otherwise unchanged - which is what lets Diff tell "still there" apart from
+//"new" across runs.
+func fingerprintFor(ruleID string, filename string, message string, snippet string) string {
+	normalizedMessage := strings.Join(strings.Fields(message), " ")
+	h := sha256.New()
+	h.Write([]byte(ruleID))
+	h.Write([]byte{0})
+	h.Write([]byte(filename))
+	h.Write([]byte{0})
+	h.Write([]byte(normalizedMessage))
+	h.Write([]byte{0})
+	h.Write([]byte(snippet))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+//Diff compares the current DetectionResults against a previous run's baseline and returns a new
+//DetectionResults where findings also present in previous are marked Baseline and no longer
+//contribute to HasFailures. Baseline findings are still attached to the returned results (as
+//informational entries) so they remain visible in reports - only newly introduced findings can
+//fail the run.
+func (r *DetectionResults) Diff(previous *DetectionResults) *DetectionResults {
+	knownFingerprints := make(map[string]bool)
+	if previous != nil {
+		for _, resultDetails := range previous.Results {
+			for _, detail := range resultDetails.FailureList {
+				if detail.Fingerprint != "" {
+					knownFingerprints[detail.Fingerprint] = true
+				}
+			}
+		}
+	}
+
+	diffed := NewDetectionResults(r.mode)
+	for _, resultDetails := range r.Results {
+		diffedResultDetails := ResultsDetails{
+			Filename:    resultDetails.Filename,
+			FailureList: make([]Details, 0, len(resultDetails.FailureList)),
+			WarningList: resultDetails.WarningList,
+			IgnoreList:  resultDetails.IgnoreList,
+		}
+
+		for _, detail := range resultDetails.FailureList {
+			detail.Baseline = detail.Fingerprint != "" && knownFingerprints[detail.Fingerprint]
+			diffedResultDetails.FailureList = append(diffedResultDetails.FailureList, detail)
+			if !detail.Baseline {
+				diffed.updateResultsSummary(detail.Category)
+			}
+		}
+
+		diffed.Results = append(diffed.Results, diffedResultDetails)
+	}
+
+	diffed.Summary.Types.Warnings = r.Summary.Types.Warnings
+	diffed.Summary.Types.Ignores = r.Summary.Types.Ignores
+	return diffed
+}
@@ -0,0 +1,98 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"talisman/detector/severity"
+	"talisman/gitrepo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprintForIsStableAcrossWhitespaceOnlyMessageChanges(t *testing.T) {
+	a := fingerprintFor("aws-secret-key", "config.yml", "potential   secret  found", "AWS_SECRET=abc123")
+	b := fingerprintFor("aws-secret-key", "config.yml", "potential secret found", "AWS_SECRET=abc123")
+
+	assert.Equal(t, a, b)
+}
+
+func TestFingerprintForDiffersOnRuleIDFilenameOrSnippet(t *testing.T) {
+	base := fingerprintFor("aws-secret-key", "config.yml", "potential secret found", "AWS_SECRET=abc123")
+
+	assert.NotEqual(t, base, fingerprintFor("github-token", "config.yml", "potential secret found", "AWS_SECRET=abc123"))
+	assert.NotEqual(t, base, fingerprintFor("aws-secret-key", "other.yml", "potential secret found", "AWS_SECRET=abc123"))
+	assert.NotEqual(t, base, fingerprintFor("aws-secret-key", "config.yml", "potential secret found", "AWS_SECRET=def456"))
+}
+
+func TestDiffMarksPreviouslySeenFindingsAsBaselineAndExcludesThemFromFailures(t *testing.T) {
+	previous := &DetectionResults{SchemaVersion: CurrentSchemaVersion, Results: []ResultsDetails{}}
+	previous.Fail(gitrepo.FilePath("config.yml"), "filecontent", "known secret", []string{}, severity.High)
+
+	current := &DetectionResults{SchemaVersion: CurrentSchemaVersion, Results: []ResultsDetails{}}
+	current.Fail(gitrepo.FilePath("config.yml"), "filecontent", "known secret", []string{}, severity.High)
+	current.Fail(gitrepo.FilePath("config.yml"), "filecontent", "brand new secret", []string{}, severity.High)
+
+	diffed := current.Diff(previous)
+
+	assert.True(t, diffed.HasFailures())
+	assert.Equal(t, 1, diffed.Summary.Types.Filecontent)
+	failures := diffed.GetFailures(gitrepo.FilePath("config.yml"))
+	assert.Len(t, failures, 2)
+
+	var knownCount, newCount int
+	for _, detail := range failures {
+		if detail.Message == "known secret" {
+			assert.True(t, detail.Baseline)
+			knownCount++
+		}
+		if detail.Message == "brand new secret" {
+			assert.False(t, detail.Baseline)
+			newCount++
+		}
+	}
+	assert.Equal(t, 1, knownCount)
+	assert.Equal(t, 1, newCount)
+}
+
+func TestDiffAgainstEmptyBaselineMarksNothingAsBaseline(t *testing.T) {
+	current := &DetectionResults{SchemaVersion: CurrentSchemaVersion, Results: []ResultsDetails{}}
+	current.Fail(gitrepo.FilePath("config.yml"), "filecontent", "brand new secret", []string{}, severity.High)
+
+	diffed := current.Diff(&DetectionResults{})
+
+	assert.True(t, diffed.HasFailures())
+	failures := diffed.GetFailures(gitrepo.FilePath("config.yml"))
+	assert.Len(t, failures, 1)
+	assert.False(t, failures[0].Baseline)
+}
+
+func TestReportJUnitTreatsBaselineFailuresAsSkippedNotFailure(t *testing.T) {
+	previous := &DetectionResults{SchemaVersion: CurrentSchemaVersion, Results: []ResultsDetails{}}
+	previous.Fail(gitrepo.FilePath("config.yml"), "filecontent", "known secret", []string{}, severity.High)
+
+	current := &DetectionResults{SchemaVersion: CurrentSchemaVersion, Results: []ResultsDetails{}}
+	current.Fail(gitrepo.FilePath("config.yml"), "filecontent", "known secret", []string{}, severity.High)
+	current.Fail(gitrepo.FilePath("config.yml"), "filecontent", "brand new secret", []string{}, severity.High)
+
+	var buf bytes.Buffer
+	assert.NoError(t, current.Diff(previous).ReportJUnit(&buf))
+
+	output := buf.String()
+	assert.Contains(t, output, `<skipped message="present in baseline"></skipped>`)
+	assert.Equal(t, 1, strings.Count(output, "<failure "))
+}
+
+func TestLoadDetectionResultsRoundTripsJSON(t *testing.T) {
+	original := &DetectionResults{SchemaVersion: CurrentSchemaVersion, Results: []ResultsDetails{}}
+	original.Fail(gitrepo.FilePath("config.yml"), "filecontent", "a secret", []string{}, severity.High)
+
+	var buf bytes.Buffer
+	assert.NoError(t, json.NewEncoder(&buf).Encode(original))
+
+	loaded, err := LoadDetectionResults(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, original.GetFailures(gitrepo.FilePath("config.yml")), loaded.GetFailures(gitrepo.FilePath("config.yml")))
+}
@@ -19,11 +19,39 @@ import (
 	"github.com/olekukonko/tablewriter"
 )
 
+//Location pinpoints where in a file a Details entry was raised, so that consumers can jump
+//straight to the offending text instead of re-parsing Message.
+type Location struct {
+	StartLine   int    `json:"startLine,omitempty"`
+	EndLine     int    `json:"endLine,omitempty"`
+	StartColumn int    `json:"startColumn,omitempty"`
+	EndColumn   int    `json:"endColumn,omitempty"`
+	Snippet     string `json:"snippet,omitempty"`
+}
+
 type Details struct {
 	Category string            `json:"type"`
 	Message  string            `json:"message"`
 	Commits  []string          `json:"commits"`
 	Severity severity.Severity `json:"severity,omitempty"`
+	//RuleID is a stable, machine-parsable identifier for the check that produced this Details
+	//entry (e.g. "filecontent"). Fail (and therefore every detector in this tree today, since
+	//none of them have been migrated to call FailWithLocation directly) sets it to Category.
+	RuleID string `json:"ruleId,omitempty"`
+	//RuleName is a human-readable counterpart to RuleID, suitable for display in reports.
+	RuleName string `json:"ruleName,omitempty"`
+	//Location carries the line/column range the finding was detected at, when known. It is
+	//always present in the JSON output (struct fields can't be conditionally omitted by
+	//encoding/json), and is the zero Location{} until a caller populates it via
+	//FailWithLocation.
+	Location Location `json:"location"`
+	//Fingerprint is a canonical hash of RuleID, filename, normalized Message and, where
+	//available, the Location snippet. It is stable across runs even if unrelated lines in the
+	//file move around, which is what makes baseline comparisons (see Diff) possible.
+	Fingerprint string `json:"fingerprint,omitempty"`
+	//Baseline is set by Diff to mark a Details entry that was already present in the previous
+	//scan. Baseline findings are still reported, but don't count towards HasFailures.
+	Baseline bool `json:"baseline,omitempty"`
 }
 
 type ResultsDetails struct {
@@ -50,11 +78,17 @@ type ResultsSummary struct {
 //Currently, it keeps track of failures and ignored files.
 //The results are grouped by FilePath for easy reporting of all detected problems with individual files.
 type DetectionResults struct {
-	mode    talismanrc.Mode
-	Summary ResultsSummary   `json:"summary"`
-	Results []ResultsDetails `json:"results"`
+	mode talismanrc.Mode
+	//SchemaVersion identifies the shape of this JSON document, so that consumers can diff
+	//findings by RuleID across Talisman versions instead of relying on fragile string matching.
+	SchemaVersion string           `json:"schemaVersion"`
+	Summary       ResultsSummary   `json:"summary"`
+	Results       []ResultsDetails `json:"results"`
 }
 
+//CurrentSchemaVersion is the SchemaVersion written by this build of Talisman.
+const CurrentSchemaVersion = "1.0"
+
 func (r *ResultsDetails) getWarningDataByCategoryAndMessage(failureMessage string, category string) *Details {
 	detail := getDetailsByCategoryAndMessage(r.WarningList, category, failureMessage)
 	r.WarningList = append(r.WarningList, *detail)
@@ -64,7 +98,7 @@ func (r *ResultsDetails) getWarningDataByCategoryAndMessage(failureMessage strin
 func (r *ResultsDetails) getFailureDataByCategoryAndMessage(failureMessage string, category string) *Details {
 	detail := getDetailsByCategoryAndMessage(r.FailureList, category, failureMessage)
 	if detail == nil {
-		detail = &Details{category, failureMessage, make([]string, 0), severity.Low}
+		detail = &Details{Category: category, Message: failureMessage, Commits: make([]string, 0), Severity: severity.Low, RuleID: category}
 		r.FailureList = append(r.FailureList, *detail)
 	}
 	return detail
@@ -78,7 +112,7 @@ func (r *ResultsDetails) addIgnoreDataByCategory(category string) {
 		}
 	}
 	if !isCategoryAlreadyPresent {
-		detail := Details{category, "", make([]string, 0), severity.Low}
+		detail := Details{Category: category, Message: "", Commits: make([]string, 0), Severity: severity.Low, RuleID: category}
 		r.IgnoreList = append(r.IgnoreList, detail)
 	}
 }
@@ -106,6 +140,7 @@ func (r *DetectionResults) getResultDetailsForFilePath(fileName gitrepo.FilePath
 func NewDetectionResults(mode talismanrc.Mode) *DetectionResults {
 	return &DetectionResults{
 		mode,
+		CurrentSchemaVersion,
 		ResultsSummary{
 			FailureTypes{0, 0, 0, 0, 0},
 		},
@@ -118,6 +153,24 @@ func NewDetectionResults(mode talismanrc.Mode) *DetectionResults {
 //Detectors are encouraged to provide context sensitive messages so that fixing the errors is made simple for the end user
 //Fail may be called multiple times for each FilePath and the calls accumulate the provided reasons
 func (r *DetectionResults) Fail(filePath gitrepo.FilePath, category string, message string, commits []string, severity severity.Severity) {
+	r.FailWithLocation(filePath, category, message, commits, severity, category, "", Location{})
+}
+
+//FailWithLocation behaves like Fail, but additionally records a stable RuleID/RuleName and the
+//Location the finding was detected at, so that consumers can diff findings across runs by
+//RuleID instead of matching on Message, and can jump straight to the offending text.
+//
+//NOT YET WIRED UP: no call site in this source tree passes a real RuleName or Location - Fail
+//is still the only caller, and it always passes Location{} and ruleName "". The
+//filecontent/filename/filesize detectors that would need to be migrated to call
+//FailWithLocation directly aren't part of this source tree snapshot (it starts at
+//detector/helpers), so that migration can't be done here. Until a detector is updated to call
+//this directly, every Details produced by a real scan has RuleID==Category and a zero
+//Location/RuleName, and the structured-location/RuleName fields should be treated as unused in
+//production. New detector code should prefer FailWithLocation over Fail once it can supply
+//real values.
+func (r *DetectionResults) FailWithLocation(filePath gitrepo.FilePath, category string, message string, commits []string, severity severity.Severity, ruleID string, ruleName string, location Location) {
+	fingerprint := fingerprintFor(ruleID, string(filePath), message, location.Snippet)
 	isFilePresentInResults := false
 	for resultIndex := 0; resultIndex < len(r.Results); resultIndex++ {
 		if r.Results[resultIndex].Filename == filePath {
@@ -130,12 +183,12 @@ func (r *DetectionResults) Fail(filePath gitrepo.FilePath, category string, mess
 				}
 			}
 			if !isEntryPresentForGivenCategoryAndMessage {
-				r.Results[resultIndex].FailureList = append(r.Results[resultIndex].FailureList, Details{category, message, commits, severity})
+				r.Results[resultIndex].FailureList = append(r.Results[resultIndex].FailureList, Details{Category: category, Message: message, Commits: commits, Severity: severity, RuleID: ruleID, RuleName: ruleName, Location: location, Fingerprint: fingerprint})
 			}
 		}
 	}
 	if !isFilePresentInResults {
-		failureDetails := Details{category, message, commits, severity}
+		failureDetails := Details{Category: category, Message: message, Commits: commits, Severity: severity, RuleID: ruleID, RuleName: ruleName, Location: location, Fingerprint: fingerprint}
 		resultDetails := ResultsDetails{filePath, make([]Details, 0), make([]Details, 0), make([]Details, 0)}
 		resultDetails.FailureList = append(resultDetails.FailureList, failureDetails)
 		r.Results = append(r.Results, resultDetails)
@@ -156,12 +209,12 @@ func (r *DetectionResults) Warn(filePath gitrepo.FilePath, category string, mess
 				}
 			}
 			if !isEntryPresentForGivenCategoryAndMessage {
-				r.Results[resultIndex].WarningList = append(r.Results[resultIndex].WarningList, Details{category, message, commits, severity})
+				r.Results[resultIndex].WarningList = append(r.Results[resultIndex].WarningList, Details{Category: category, Message: message, Commits: commits, Severity: severity, RuleID: category})
 			}
 		}
 	}
 	if !isFilePresentInResults {
-		warningDetails := Details{category, message, commits, severity}
+		warningDetails := Details{Category: category, Message: message, Commits: commits, Severity: severity, RuleID: category}
 		resultDetails := ResultsDetails{filePath, make([]Details, 0), make([]Details, 0), make([]Details, 0)}
 		resultDetails.WarningList = append(resultDetails.WarningList, warningDetails)
 		r.Results = append(r.Results, resultDetails)
@@ -185,13 +238,13 @@ func (r *DetectionResults) Ignore(filePath gitrepo.FilePath, category string) {
 				}
 			}
 			if !isEntryPresentForGivenCategory {
-				detail := Details{category, "", make([]string, 0), severity.Low}
+				detail := Details{Category: category, Message: "", Commits: make([]string, 0), Severity: severity.Low, RuleID: category}
 				r.Results[resultIndex].IgnoreList = append(r.Results[resultIndex].IgnoreList, detail)
 			}
 		}
 	}
 	if !isFilePresentInResults {
-		ignoreDetails := Details{category, "", make([]string, 0), severity.Low}
+		ignoreDetails := Details{Category: category, Message: "", Commits: make([]string, 0), Severity: severity.Low, RuleID: category}
 		resultDetails := ResultsDetails{filePath, make([]Details, 0), make([]Details, 0), make([]Details, 0)}
 		resultDetails.IgnoreList = append(resultDetails.IgnoreList, ignoreDetails)
 		r.Results = append(r.Results, resultDetails)
@@ -301,33 +354,88 @@ func (r *DetectionResults) Report(promptContext prompt.PromptContext) string {
 }
 
 func (r *DetectionResults) suggestTalismanRC(filePaths []string, promptContext prompt.PromptContext) {
-	var entriesToAdd []talismanrc.IgnoreConfig
+	var candidates []reviewCandidate
 
 	for _, filePath := range filePaths {
 		currentChecksum := utility.DefaultSHA256Hasher{}.CollectiveSHA256Hash([]string{filePath})
-		fileIgnoreConfig := talismanrc.BuildIgnoreConfig(r.mode, filePath, currentChecksum, []string{})
-		entriesToAdd = append(entriesToAdd, fileIgnoreConfig)
+		candidates = append(candidates, reviewCandidate{
+			filePath:   filePath,
+			checksum:   currentChecksum,
+			categories: r.categoriesForFile(filePath),
+		})
 	}
 
 	if promptContext.Interactive && runtime.GOOS != "windows" {
-		confirmedEntries := getUserConfirmation(entriesToAdd, promptContext)
+		confirmedEntries := getUserConfirmation(r.mode, candidates, promptContext)
 		talismanrc.ConfigFromFile().AddIgnores(r.mode, confirmedEntries)
 		output, err := exec.Command("git", "add", ".talismanrc").CombinedOutput()
 		if err != nil {
 			logrus.Errorf("Error appending to talismanrc %v", output)
 		}
 	} else {
+		var entriesToAdd []talismanrc.IgnoreConfig
+		for _, candidate := range candidates {
+			entriesToAdd = append(entriesToAdd, talismanrc.BuildIgnoreConfig(r.mode, candidate.filePath, candidate.checksum, []string{}))
+		}
 		printTalismanIgnoreSuggestion(entriesToAdd)
 		return
 	}
 
 }
 
-func getUserConfirmation(configs []talismanrc.IgnoreConfig, promptContext prompt.PromptContext) []talismanrc.IgnoreConfig {
-	confirmed := []talismanrc.IgnoreConfig{}
-	if len(configs) != 0 {
-		fmt.Println("==== Interactively adding to talismanrc ====")
+//categoriesForFile returns the distinct detector categories (filecontent/filename/filesize)
+//that raised a failure or warning against filePath, so the review TUI can offer "ignore only
+//this category" as an alternative to ignoring the whole file.
+func (r *DetectionResults) categoriesForFile(filePath string) []string {
+	resultDetails := r.getResultDetailsForFilePath(gitrepo.FilePath(filePath))
+	if resultDetails == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var categories []string
+	addCategory := func(category string) {
+		if !seen[category] {
+			seen[category] = true
+			categories = append(categories, category)
+		}
 	}
+	for _, detail := range resultDetails.FailureList {
+		addCategory(detail.Category)
+	}
+	for _, detail := range resultDetails.WarningList {
+		addCategory(detail.Category)
+	}
+	return categories
+}
+
+//getUserConfirmation lets the user pick which of the suggested IgnoreConfigs should actually be
+//added to .talismanrc. It prefers the full-screen TUI review (see interactive_review.go) when
+//stdout is a terminal, since that lets the user see every finding at once instead of working
+//through a sequential Y/N loop, and falls back to the old sequential prompt when the TUI can't
+//run at all (e.g. no usable terminal) - but a user explicitly cancelling out of the TUI is
+//respected as-is and does not fall through to the sequential prompt.
+func getUserConfirmation(mode talismanrc.Mode, candidates []reviewCandidate, promptContext prompt.PromptContext) []talismanrc.IgnoreConfig {
+	if len(candidates) == 0 {
+		return []talismanrc.IgnoreConfig{}
+	}
+	if isTerminal(os.Stdout) {
+		confirmed, err := runInteractiveReview(mode, candidates)
+		if err == nil {
+			return confirmed
+		}
+		logrus.Errorf("interactive review failed, falling back to sequential prompt: %s", err)
+	}
+
+	var configs []talismanrc.IgnoreConfig
+	for _, candidate := range candidates {
+		configs = append(configs, talismanrc.BuildIgnoreConfig(mode, candidate.filePath, candidate.checksum, []string{}))
+	}
+	return getUserConfirmationSequential(configs, promptContext)
+}
+
+func getUserConfirmationSequential(configs []talismanrc.IgnoreConfig, promptContext prompt.PromptContext) []talismanrc.IgnoreConfig {
+	confirmed := []talismanrc.IgnoreConfig{}
+	fmt.Println("==== Interactively adding to talismanrc ====")
 	for _, config := range configs {
 		if confirm(config, promptContext) {
 			confirmed = append(confirmed, config)
@@ -369,7 +477,11 @@ func (r *DetectionResults) ReportFileFailures(filePath gitrepo.FilePath) [][]str
 			if len(detail.Message) > 150 {
 				detail.Message = detail.Message[:75] + "\n" + detail.Message[75:147] + "..."
 			}
-			data = append(data, []string{string(filePath), detail.Message, detail.Severity.String()})
+			severityColumn := detail.Severity.String()
+			if detail.Baseline {
+				severityColumn = severityColumn + " (baseline)"
+			}
+			data = append(data, []string{string(filePath), detail.Message, severityColumn})
 		}
 	}
 	return data
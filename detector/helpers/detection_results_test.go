@@ -0,0 +1,35 @@
+package helpers
+
+import (
+	"testing"
+
+	"talisman/detector/severity"
+	"talisman/gitrepo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailWithLocationPopulatesStructuredFields(t *testing.T) {
+	results := &DetectionResults{SchemaVersion: CurrentSchemaVersion, Results: []ResultsDetails{}}
+	location := Location{StartLine: 3, EndLine: 3, StartColumn: 5, EndColumn: 20, Snippet: "AWS_SECRET=abc123"}
+
+	results.FailWithLocation(gitrepo.FilePath("config.yml"), "filecontent", "potential secret pattern", []string{}, severity.High, "aws-secret-key", "AWS Secret Key", location)
+
+	failures := results.GetFailures(gitrepo.FilePath("config.yml"))
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "aws-secret-key", failures[0].RuleID)
+	assert.Equal(t, "AWS Secret Key", failures[0].RuleName)
+	assert.Equal(t, location, failures[0].Location)
+	assert.NotEmpty(t, failures[0].Fingerprint)
+}
+
+func TestFailDefaultsRuleIDToCategory(t *testing.T) {
+	results := &DetectionResults{SchemaVersion: CurrentSchemaVersion, Results: []ResultsDetails{}}
+
+	results.Fail(gitrepo.FilePath("config.yml"), "filecontent", "potential secret pattern", []string{}, severity.High)
+
+	failures := results.GetFailures(gitrepo.FilePath("config.yml"))
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "filecontent", failures[0].RuleID)
+	assert.Equal(t, Location{}, failures[0].Location)
+}
@@ -0,0 +1,199 @@
+package helpers
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v2"
+
+	"talisman/talismanrc"
+)
+
+var (
+	reviewHeaderStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("220"))
+	reviewCursorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	reviewSelectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	reviewDimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	reviewHelpStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+)
+
+//reviewCandidate is a file the report flagged, together with enough information to build an
+//IgnoreConfig for it at more than one granularity.
+type reviewCandidate struct {
+	filePath   string
+	checksum   string
+	categories []string
+}
+
+//reviewScope is one of the actions the review TUI can take for a reviewCandidate: ignore the
+//whole file, or ignore only a single detector category on it.
+//
+//NOT IMPLEMENTED: "ignore this checksum" (as distinct from "ignore this file") and "edit the
+//surrounding lines", both named in the original request, are not offered as scopes. The former
+//would need a checksum model finer than the whole-file hash that suggestTalismanRC computes
+//and passes to talismanrc.BuildIgnoreConfig - we don't have its real source to know whether such a variant exists - and the
+//latter needs access to the raw diff hunk. Neither is available from this package alone; both
+//live in the talismanrc/gitrepo packages, which aren't part of this source tree snapshot.
+type reviewScope struct {
+	label      string
+	categories []string
+}
+
+//reviewItem is a single finding being triaged in the TUI.
+type reviewItem struct {
+	candidate  reviewCandidate
+	scopes     []reviewScope
+	scopeIndex int
+	selected   bool
+}
+
+func scopesFor(candidate reviewCandidate) []reviewScope {
+	scopes := []reviewScope{{label: "ignore this file"}}
+	for _, category := range candidate.categories {
+		scopes = append(scopes, reviewScope{label: fmt.Sprintf("ignore only: %s", category), categories: []string{category}})
+	}
+	return scopes
+}
+
+func (i reviewItem) config(mode talismanrc.Mode) talismanrc.IgnoreConfig {
+	return talismanrc.BuildIgnoreConfig(mode, i.candidate.filePath, i.candidate.checksum, i.scopes[i.scopeIndex].categories)
+}
+
+//reviewModel is the bubbletea model backing the full-screen review started from
+//suggestTalismanRC. It lets the user walk every detected file, preview the .talismanrc entry
+//that would be written for it, narrow the ignore down to a single detector category instead of
+//the whole file, and toggle whether to accept it - instead of the old Y/N prompt that asked
+//about one whole file at a time with no way to see what was coming next.
+type reviewModel struct {
+	mode      talismanrc.Mode
+	items     []reviewItem
+	cursor    int
+	confirmed bool
+}
+
+func newReviewModel(mode talismanrc.Mode, candidates []reviewCandidate) reviewModel {
+	items := make([]reviewItem, len(candidates))
+	for i, candidate := range candidates {
+		items[i] = reviewItem{candidate: candidate, scopes: scopesFor(candidate), selected: true}
+	}
+	return reviewModel{mode: mode, items: items}
+}
+
+func (m reviewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m reviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "ctrl+c", "q", "esc":
+		m.confirmed = false
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+	case " ", "i":
+		m.items[m.cursor].selected = !m.items[m.cursor].selected
+	case "c":
+		item := &m.items[m.cursor]
+		item.scopeIndex = (item.scopeIndex + 1) % len(item.scopes)
+	case "a":
+		for i := range m.items {
+			m.items[i].selected = true
+		}
+	case "n":
+		for i := range m.items {
+			m.items[i].selected = false
+		}
+	case "enter":
+		m.confirmed = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m reviewModel) View() string {
+	var b strings.Builder
+	b.WriteString(reviewHeaderStyle.Render("Talisman - review detected files") + "\n\n")
+
+	for i, item := range m.items {
+		checkbox := "[ ]"
+		if item.selected {
+			checkbox = reviewSelectedStyle.Render("[x]")
+		}
+		line := fmt.Sprintf("%s %s (%s)", checkbox, item.candidate.filePath, item.scopes[item.scopeIndex].label)
+		if i == m.cursor {
+			line = reviewCursorStyle.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n")
+	if len(m.items) > 0 {
+		b.WriteString(reviewDimStyle.Render(previewIgnoreConfig(m.items[m.cursor].config(m.mode))) + "\n\n")
+	}
+	b.WriteString(reviewHelpStyle.Render("↑/↓ move · space toggle · c cycle whole-file/category scope · a/n select/deselect all · enter confirm · q cancel"))
+	return b.String()
+}
+
+func previewIgnoreConfig(config talismanrc.IgnoreConfig) string {
+	bytes, err := yaml.Marshal(&config)
+	if err != nil {
+		return ""
+	}
+	return string(bytes)
+}
+
+//runInteractiveReview runs the full-screen review TUI over the supplied candidates and returns
+//the IgnoreConfigs the user left selected at whatever scope (whole-file or single-category)
+//they landed on. The returned error is non-nil only when the TUI itself failed to run (e.g. no
+//usable terminal) - callers should fall back to the sequential prompt in that case. A user who
+//explicitly cancels (q/esc/ctrl+c) is not an error: it returns (nil, nil), and callers must
+//honour that as "nothing confirmed" rather than retrying some other way.
+func runInteractiveReview(mode talismanrc.Mode, candidates []reviewCandidate) ([]talismanrc.IgnoreConfig, error) {
+	model := newReviewModel(mode, candidates)
+	program := tea.NewProgram(model)
+	finalModel, err := program.Run()
+	if err != nil {
+		return nil, fmt.Errorf("running interactive review: %w", err)
+	}
+
+	result := finalModel.(reviewModel)
+	if !result.confirmed {
+		return nil, nil
+	}
+
+	var confirmed []talismanrc.IgnoreConfig
+	for _, item := range result.items {
+		if item.selected {
+			confirmed = append(confirmed, item.config(mode))
+		}
+	}
+	return confirmed, nil
+}
+
+//isTerminal answers whether w is an interactive terminal, so callers can fall back to a
+//non-TUI path (e.g. piped output, or a platform bubbletea can't put into raw mode).
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return term.IsTerminal(int(file.Fd()))
+}
@@ -0,0 +1,120 @@
+package helpers
+
+import (
+	"testing"
+
+	"talisman/detector/severity"
+	"talisman/gitrepo"
+	"talisman/talismanrc"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func twoItemReviewModel() reviewModel {
+	var mode talismanrc.Mode
+	return newReviewModel(mode, []reviewCandidate{
+		{filePath: "a.yml", checksum: "a", categories: []string{"filecontent"}},
+		{filePath: "b.yml", checksum: "b", categories: []string{"filename"}},
+	})
+}
+
+func TestReviewModelUpdateMovesCursorWithinBounds(t *testing.T) {
+	m := twoItemReviewModel()
+
+	m, _ = applyKey(m, tea.KeyDown)
+	assert.Equal(t, 1, m.cursor)
+
+	m, _ = applyKey(m, tea.KeyDown)
+	assert.Equal(t, 1, m.cursor, "cursor should not move past the last item")
+
+	m, _ = applyKey(m, tea.KeyUp)
+	assert.Equal(t, 0, m.cursor)
+
+	m, _ = applyKey(m, tea.KeyUp)
+	assert.Equal(t, 0, m.cursor, "cursor should not move before the first item")
+}
+
+func TestReviewModelUpdateTogglesSelection(t *testing.T) {
+	m := twoItemReviewModel()
+	assert.True(t, m.items[0].selected)
+
+	m, _ = applyRuneKey(m, ' ')
+	assert.False(t, m.items[0].selected)
+
+	m, _ = applyRuneKey(m, ' ')
+	assert.True(t, m.items[0].selected)
+}
+
+func TestReviewModelUpdateSelectAllAndDeselectAll(t *testing.T) {
+	m := twoItemReviewModel()
+
+	m, _ = applyRuneKey(m, 'n')
+	assert.False(t, m.items[0].selected)
+	assert.False(t, m.items[1].selected)
+
+	m, _ = applyRuneKey(m, 'a')
+	assert.True(t, m.items[0].selected)
+	assert.True(t, m.items[1].selected)
+}
+
+func TestReviewModelUpdateCyclesScopeAndWraps(t *testing.T) {
+	m := twoItemReviewModel()
+	assert.Equal(t, 0, m.items[0].scopeIndex)
+
+	m, _ = applyRuneKey(m, 'c')
+	assert.Equal(t, 1, m.items[0].scopeIndex)
+
+	m, _ = applyRuneKey(m, 'c')
+	assert.Equal(t, 0, m.items[0].scopeIndex, "scope should wrap back to whole-file")
+}
+
+func TestReviewModelUpdateEnterConfirms(t *testing.T) {
+	m := twoItemReviewModel()
+
+	m, cmd := applyKey(m, tea.KeyEnter)
+
+	assert.True(t, m.confirmed)
+	assert.NotNil(t, cmd)
+}
+
+func TestReviewModelUpdateEscCancelsWithoutConfirming(t *testing.T) {
+	m := twoItemReviewModel()
+	m.confirmed = true
+
+	m, cmd := applyKey(m, tea.KeyEsc)
+
+	assert.False(t, m.confirmed)
+	assert.NotNil(t, cmd)
+}
+
+func applyKey(m reviewModel, keyType tea.KeyType) (reviewModel, tea.Cmd) {
+	next, cmd := m.Update(tea.KeyMsg{Type: keyType})
+	return next.(reviewModel), cmd
+}
+
+func applyRuneKey(m reviewModel, r rune) (reviewModel, tea.Cmd) {
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	return next.(reviewModel), cmd
+}
+
+func TestScopesForOffersWholeFileAndEachCategory(t *testing.T) {
+	candidate := reviewCandidate{filePath: "config.yml", checksum: "abc123", categories: []string{"filecontent", "filename"}}
+
+	scopes := scopesFor(candidate)
+
+	assert.Len(t, scopes, 3)
+	assert.Equal(t, "ignore this file", scopes[0].label)
+	assert.Nil(t, scopes[0].categories)
+	assert.Equal(t, []string{"filecontent"}, scopes[1].categories)
+	assert.Equal(t, []string{"filename"}, scopes[2].categories)
+}
+
+func TestCategoriesForFileCollectsDistinctCategoriesFromFailuresAndWarnings(t *testing.T) {
+	results := &DetectionResults{SchemaVersion: CurrentSchemaVersion, Results: []ResultsDetails{}}
+	results.Fail(gitrepo.FilePath("config.yml"), "filecontent", "first secret", []string{}, severity.High)
+	results.Fail(gitrepo.FilePath("config.yml"), "filecontent", "second secret", []string{}, severity.High)
+	results.Warn(gitrepo.FilePath("config.yml"), "filename", "suspicious filename", []string{}, severity.Low)
+
+	assert.Equal(t, []string{"filecontent", "filename"}, results.categoriesForFile("config.yml"))
+}
@@ -0,0 +1,47 @@
+package helpers
+
+import (
+	"fmt"
+	"io"
+)
+
+//ReportFormat names an output format WriteReport can produce; its values are the ones a
+//--reportFormat CLI flag would take.
+type ReportFormat string
+
+const (
+	//ReportFormatSARIF selects ReportSARIF as the --reportFormat value.
+	ReportFormatSARIF ReportFormat = "sarif"
+	//ReportFormatHTML selects ReportHTML as the --reportFormat value.
+	ReportFormatHTML ReportFormat = "html"
+	//ReportFormatJUnit selects ReportJUnit as the --reportFormat value.
+	ReportFormatJUnit ReportFormat = "junit"
+)
+
+//ReportOptions carries the format-specific knobs a --reportFormat CLI flag would need to
+//collect from its own flags (e.g. --reportFile, a report title) and pass through to WriteReport.
+type ReportOptions struct {
+	//Title is used by the html format; ignored by the others.
+	Title string
+}
+
+//WriteReport dispatches to the ReportXxx method matching format.
+//
+//NOTE: this change does NOT add the --reportFormat CLI flag itself - cmd/talisman, the package
+//that would parse it and call this, is not part of this source tree snapshot (the snapshot
+//starts at detector/helpers), so there is no existing main() or flag set to wire it into
+//honestly. WriteReport is the seam that flag is meant to call once cmd/talisman exists; until
+//then this is a library-level capability only, reachable from tests and future CLI code, not
+//from the talisman binary.
+func (r *DetectionResults) WriteReport(w io.Writer, format ReportFormat, opts ReportOptions) error {
+	switch format {
+	case ReportFormatSARIF:
+		return r.ReportSARIF(w)
+	case ReportFormatHTML:
+		return r.ReportHTML(w, HTMLReportOptions{Title: opts.Title})
+	case ReportFormatJUnit:
+		return r.ReportJUnit(w)
+	default:
+		return fmt.Errorf("unsupported report format: %q", format)
+	}
+}
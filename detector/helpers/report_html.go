@@ -0,0 +1,177 @@
+package helpers
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2/quick"
+)
+
+//HTMLReportOptions controls the rendering of DetectionResults.ReportHTML.
+type HTMLReportOptions struct {
+	//Title is shown as the page heading, e.g. the repository or project name.
+	Title string
+}
+
+type htmlFileReport struct {
+	Filename  string
+	Failures  []htmlDetail
+	Warnings  []htmlDetail
+	Ignores   []htmlDetail
+}
+
+type htmlDetail struct {
+	Category string
+	Message  string
+	Severity string
+	//SeverityClass is Severity lowercased, since severity.Severity.String() returns the
+	//capitalized form ("High"/"Medium"/"Low") used for display elsewhere, but the badge-{high,
+	//medium,low} CSS classes below are lowercase.
+	SeverityClass string
+	Snippet       template.HTML
+	Baseline      bool
+}
+
+type htmlReportData struct {
+	Title        string
+	HasFailures  bool
+	HasWarnings  bool
+	HasIgnores   bool
+	Files        []htmlFileReport
+}
+
+const htmlReportTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.4em; }
+details { border: 1px solid #ddd; border-radius: 4px; margin-bottom: 0.75em; padding: 0.5em 0.75em; }
+summary { cursor: pointer; font-weight: 600; }
+.badge { display: inline-block; border-radius: 3px; padding: 0.1em 0.5em; margin-left: 0.5em; font-size: 0.8em; color: #fff; }
+.badge-high { background: #c0392b; }
+.badge-medium { background: #d68910; }
+.badge-low { background: #7f8c8d; }
+.badge-warning { background: #e67e22; }
+.badge-ignore { background: #7f8c8d; }
+.badge-baseline { background: #95a5a6; }
+.finding { margin: 0.5em 0; }
+.finding-baseline { opacity: 0.6; }
+pre { background: #f7f7f7; padding: 0.5em; overflow-x: auto; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{range .Files}}
+<details open>
+<summary>{{.Filename}}</summary>
+{{range .Failures}}
+<div class="finding{{if .Baseline}} finding-baseline{{end}}">
+<span class="badge badge-{{.SeverityClass}}">{{.Severity}}</span>{{if .Baseline}} <span class="badge badge-baseline">baseline</span>{{end}} <strong>{{.Category}}</strong>: {{.Message}}
+{{if .Snippet}}<pre>{{.Snippet}}</pre>{{end}}
+</div>
+{{end}}
+{{if .Warnings}}
+<details>
+<summary>Warnings ({{len .Warnings}})</summary>
+{{range .Warnings}}
+<div class="finding">
+<span class="badge badge-warning">warning</span> <strong>{{.Category}}</strong>: {{.Message}}
+{{if .Snippet}}<pre>{{.Snippet}}</pre>{{end}}
+</div>
+{{end}}
+</details>
+{{end}}
+{{if .Ignores}}
+<details>
+<summary>Ignored ({{len .Ignores}})</summary>
+{{range .Ignores}}
+<div class="finding"><span class="badge badge-ignore">ignored</span> <strong>{{.Category}}</strong></div>
+{{end}}
+</details>
+{{end}}
+</details>
+{{end}}
+</body>
+</html>
+`
+
+//ReportHTML renders a self-contained HTML page documenting the current DetectionResults,
+//suitable for attaching to a PR or CI run. Failures are always expanded; warnings and
+//ignores are grouped into collapsible sections per file. Failures carried over from a
+//baseline via Diff render dimmed with a "baseline" badge, so they stay visible without
+//looking like something that just broke the build.
+//
+//Two things this change does NOT do, despite being asked for:
+//  - The syntax-highlighted snippet under each finding is sourced from Details.Location.Snippet,
+//    which (see FailWithLocation's doc comment in detection_results.go) no detector in this tree
+//    populates today; until a detector calls FailWithLocation with a real snippet, every finding
+//    renders without one and only the category/message/severity line is shown.
+//  - There is no --reportFormat html --reportFile flag: cmd/talisman, which would parse it and
+//    call ReportHTML, is not part of this source tree snapshot. ReportHTML is reachable today
+//    only from Go code (tests, or future CLI code) that calls it directly.
+func (r *DetectionResults) ReportHTML(w io.Writer, opts HTMLReportOptions) error {
+	tmpl, err := template.New("report").Parse(htmlReportTemplate)
+	if err != nil {
+		return err
+	}
+
+	data := htmlReportData{
+		Title:       opts.Title,
+		HasFailures: r.HasFailures(),
+		HasWarnings: r.HasWarnings(),
+		HasIgnores:  r.HasIgnores(),
+	}
+	if data.Title == "" {
+		data.Title = "Talisman Report"
+	}
+
+	for _, resultDetails := range r.Results {
+		if len(resultDetails.FailureList) == 0 && len(resultDetails.WarningList) == 0 && len(resultDetails.IgnoreList) == 0 {
+			continue
+		}
+		fileReport := htmlFileReport{Filename: string(resultDetails.Filename)}
+		for _, detail := range resultDetails.FailureList {
+			fileReport.Failures = append(fileReport.Failures, toHTMLDetail(detail))
+		}
+		for _, detail := range resultDetails.WarningList {
+			fileReport.Warnings = append(fileReport.Warnings, toHTMLDetail(detail))
+		}
+		for _, detail := range resultDetails.IgnoreList {
+			fileReport.Ignores = append(fileReport.Ignores, toHTMLDetail(detail))
+		}
+		data.Files = append(data.Files, fileReport)
+	}
+
+	return tmpl.Execute(w, data)
+}
+
+func toHTMLDetail(detail Details) htmlDetail {
+	return htmlDetail{
+		Category:      detail.Category,
+		Message:       detail.Message,
+		Severity:      detail.Severity.String(),
+		SeverityClass: strings.ToLower(detail.Severity.String()),
+		Snippet:       highlightSnippet(detail.Location.Snippet),
+		Baseline:      detail.Baseline,
+	}
+}
+
+//highlightSnippet runs the supplied source through chroma to produce syntax-highlighted HTML.
+//Talisman doesn't know the source language of a snippet, so chroma's lexer auto-detection is
+//used, and anything it can't classify is rendered with chroma's plain-text fallback lexer. If
+//highlighting fails outright, the HTML-escaped snippet is returned unhighlighted instead.
+func highlightSnippet(snippet string) template.HTML {
+	if snippet == "" {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := quick.Highlight(&buf, snippet, "autodetect", "html", "friendly"); err != nil {
+		return template.HTML(template.HTMLEscapeString(snippet))
+	}
+	return template.HTML(buf.String())
+}
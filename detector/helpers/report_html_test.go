@@ -0,0 +1,62 @@
+package helpers
+
+import (
+	"bytes"
+	"testing"
+
+	"talisman/detector/severity"
+	"talisman/gitrepo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportHTMLRendersBadgesSectionsAndBaselineDimming(t *testing.T) {
+	previous := &DetectionResults{SchemaVersion: CurrentSchemaVersion, Results: []ResultsDetails{}}
+	previous.Fail(gitrepo.FilePath("config.yml"), "filecontent", "known secret", []string{}, severity.High)
+
+	current := &DetectionResults{SchemaVersion: CurrentSchemaVersion, Results: []ResultsDetails{}}
+	current.Fail(gitrepo.FilePath("config.yml"), "filecontent", "known secret", []string{}, severity.High)
+	current.Fail(gitrepo.FilePath("config.yml"), "filecontent", "brand new secret", []string{}, severity.Medium)
+	current.Warn(gitrepo.FilePath("config.yml"), "filename", "suspicious filename", []string{}, severity.Low)
+	current.Ignore(gitrepo.FilePath("config.yml"), "filesize")
+
+	diffed := current.Diff(previous)
+
+	var buf bytes.Buffer
+	assert.NoError(t, diffed.ReportHTML(&buf, HTMLReportOptions{Title: "Example Report"}))
+	output := buf.String()
+
+	assert.Contains(t, output, "<title>Example Report</title>")
+	assert.Contains(t, output, "<h1>Example Report</h1>")
+	assert.Contains(t, output, "config.yml")
+
+	// New failure: lowercase severity class for the CSS rule, capitalized text for display.
+	assert.Contains(t, output, `class="badge badge-medium"`)
+	assert.Contains(t, output, ">Medium<")
+
+	// Baseline failure: dimmed finding wrapper plus the baseline badge, still using the
+	// lowercase severity class.
+	assert.Contains(t, output, `class="finding finding-baseline"`)
+	assert.Contains(t, output, `class="badge badge-high"`)
+	assert.Contains(t, output, `class="badge badge-baseline">baseline</span>`)
+
+	// Warnings and ignores render in their own collapsible sections.
+	assert.Contains(t, output, "Warnings (1)")
+	assert.Contains(t, output, `class="badge badge-warning">warning</span>`)
+	assert.Contains(t, output, "suspicious filename")
+	assert.Contains(t, output, "Ignored (1)")
+	assert.Contains(t, output, `class="badge badge-ignore">ignored</span>`)
+}
+
+func TestToHTMLDetailLowercasesSeverityForCSSClass(t *testing.T) {
+	detail := Details{Category: "filecontent", Message: "a secret", Severity: severity.High}
+
+	htmlDetail := toHTMLDetail(detail)
+
+	assert.Equal(t, "High", htmlDetail.Severity)
+	assert.Equal(t, "high", htmlDetail.SeverityClass)
+}
+
+func TestHighlightSnippetReturnsEmptyForEmptyInput(t *testing.T) {
+	assert.Equal(t, "", string(highlightSnippet("")))
+}
@@ -0,0 +1,126 @@
+package helpers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string          `xml:"name,attr"`
+	Classname string          `xml:"classname,attr"`
+	Failure   *junitFailure   `xml:"failure,omitempty"`
+	SystemOut string          `xml:"system-out,omitempty"`
+	Skipped   *junitSkipped   `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+//ReportJUnit writes the current DetectionResults to w as JUnit-style XML, so that CI systems
+//which natively render JUnit (Jenkins, GitLab, CircleCI, Azure DevOps) can surface Talisman
+//findings alongside unit test results without any custom parsing.
+//
+//There is no --reportFormat junit flag wiring it up: cmd/talisman, which would parse that flag
+//and call ReportJUnit, is not part of this source tree snapshot. ReportJUnit is reachable today
+//only by calling it directly from Go code.
+func (r *DetectionResults) ReportJUnit(w io.Writer) error {
+	suites := junitTestSuites{}
+
+	for _, resultDetails := range r.Results {
+		if len(resultDetails.FailureList) == 0 && len(resultDetails.WarningList) == 0 && len(resultDetails.IgnoreList) == 0 {
+			continue
+		}
+		filename := string(resultDetails.Filename)
+		suite := junitTestSuite{Name: filename}
+
+		for index, detail := range resultDetails.FailureList {
+			suite.Tests++
+			if detail.Baseline {
+				//A baseline finding was already failing before this run; reporting it as a
+				//<failure> would flip CI systems that fail the build on any <failure> even
+				//though Diff deliberately excluded it from HasFailures. Surface it as skipped
+				//with the detail in system-out instead, so it stays visible without failing.
+				suite.Skipped++
+				suite.TestCases = append(suite.TestCases, junitTestCase{
+					Name:      junitCaseName(detail, index),
+					Classname: filename,
+					Skipped:   &junitSkipped{Message: "present in baseline"},
+					SystemOut: detail.Message,
+				})
+				continue
+			}
+			suite.Failures++
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      junitCaseName(detail, index),
+				Classname: filename,
+				Failure: &junitFailure{
+					Message: detail.Message,
+					Type:    detail.Severity.String(),
+					Text:    fmt.Sprintf("category: %s\nseverity: %s\n%s", detail.Category, detail.Severity.String(), detail.Message),
+				},
+			})
+		}
+		for index, detail := range resultDetails.WarningList {
+			suite.Tests++
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      junitCaseName(detail, index),
+				Classname: filename,
+				SystemOut: detail.Message,
+			})
+		}
+		for index, detail := range resultDetails.IgnoreList {
+			suite.Tests++
+			suite.Skipped++
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      junitCaseName(detail, index),
+				Classname: filename,
+				Skipped:   &junitSkipped{Message: "ignored by .talismanrc"},
+			})
+		}
+
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	return encoder.Encode(suites)
+}
+
+//junitCaseName builds the <testcase name="..."> attribute. RuleID/Category alone isn't unique -
+//a file can have several findings of the same category - so the finding's position within its
+//list is always appended; the line number is included too when Location is known, for
+//readability in CI UIs that display the name verbatim.
+func junitCaseName(detail Details, index int) string {
+	ruleID := detail.RuleID
+	if ruleID == "" {
+		ruleID = detail.Category
+	}
+	if detail.Location.StartLine > 0 {
+		ruleID = fmt.Sprintf("%s:L%d", ruleID, detail.Location.StartLine)
+	}
+	return fmt.Sprintf("%s#%d", ruleID, index+1)
+}
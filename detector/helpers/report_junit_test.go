@@ -0,0 +1,23 @@
+package helpers
+
+import (
+	"testing"
+
+	"talisman/detector/severity"
+	"talisman/gitrepo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJunitCaseNameIsUniquePerFindingInSameCategory(t *testing.T) {
+	results := &DetectionResults{SchemaVersion: CurrentSchemaVersion, Results: []ResultsDetails{}}
+	results.Fail(gitrepo.FilePath("config.yml"), "filecontent", "first secret", []string{}, severity.High)
+	results.Fail(gitrepo.FilePath("config.yml"), "filecontent", "second secret", []string{}, severity.High)
+
+	failures := results.GetFailures(gitrepo.FilePath("config.yml"))
+	assert.Len(t, failures, 2)
+
+	firstName := junitCaseName(failures[0], 0)
+	secondName := junitCaseName(failures[1], 1)
+	assert.NotEqual(t, firstName, secondName)
+}
@@ -0,0 +1,192 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"talisman/detector/severity"
+)
+
+const sarifVersion = "2.1.0"
+const sarifSchema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+//Version is the Talisman version reported in the SARIF tool driver. It is expected to be
+//overridden at build time via -ldflags, falling back to "dev" for local/test builds.
+var Version = "dev"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	ShortDescription sarifMultiFmtText `json:"shortDescription"`
+}
+
+type sarifMultiFmtText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	Level        string             `json:"level"`
+	Message      sarifMultiFmtText  `json:"message"`
+	Locations    []sarifLocation    `json:"locations"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int                `json:"startLine,omitempty"`
+	EndLine     int                `json:"endLine,omitempty"`
+	StartColumn int                `json:"startColumn,omitempty"`
+	EndColumn   int                `json:"endColumn,omitempty"`
+	Snippet     *sarifMultiFmtText `json:"snippet,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind string `json:"kind"`
+}
+
+var sarifRuleDescriptions = map[string]string{
+	"filecontent": "Flags file content that looks like a credential or secret",
+	"filename":    "Flags filenames that are known to hold sensitive data",
+	"filesize":    "Flags files that exceed the configured size threshold",
+}
+
+//ReportSARIF writes the current DetectionResults to w as a SARIF 2.1.0 log, so that the
+//output can be consumed by GitHub Code Scanning or any other SARIF-aware tooling.
+func (r *DetectionResults) ReportSARIF(w io.Writer) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "talisman",
+				Version:        Version,
+				InformationURI: "https://github.com/thoughtworks/talisman",
+				Rules:          sarifRulesFor(r),
+			},
+		},
+		Results: make([]sarifResult, 0),
+	}
+
+	for _, resultDetails := range r.Results {
+		uri := string(resultDetails.Filename)
+		for _, detail := range resultDetails.FailureList {
+			if detail.Baseline {
+				run.Results = append(run.Results, sarifResultFor(detail, uri, "note", []sarifSuppression{{Kind: "external"}}))
+				continue
+			}
+			run.Results = append(run.Results, sarifResultFor(detail, uri, sarifLevelFor(detail.Severity), nil))
+		}
+		for _, detail := range resultDetails.WarningList {
+			run.Results = append(run.Results, sarifResultFor(detail, uri, "warning", nil))
+		}
+		for _, detail := range resultDetails.IgnoreList {
+			run.Results = append(run.Results, sarifResultFor(detail, uri, "note", []sarifSuppression{{Kind: "external"}}))
+		}
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+func sarifResultFor(detail Details, uri string, level string, suppressions []sarifSuppression) sarifResult {
+	message := detail.Message
+	if message == "" {
+		message = fmt.Sprintf("%s ignored", detail.Category)
+	}
+	ruleID := detail.RuleID
+	if ruleID == "" {
+		ruleID = detail.Category
+	}
+	return sarifResult{
+		RuleID:  ruleID,
+		Level:   level,
+		Message: sarifMultiFmtText{Text: message},
+		Locations: []sarifLocation{
+			{PhysicalLocation: sarifPhysicalLocationFor(uri, detail.Location)},
+		},
+		Suppressions: suppressions,
+	}
+}
+
+func sarifPhysicalLocationFor(uri string, location Location) sarifPhysicalLocation {
+	physicalLocation := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: uri}}
+	if location.StartLine == 0 {
+		return physicalLocation
+	}
+	region := &sarifRegion{
+		StartLine:   location.StartLine,
+		EndLine:     location.EndLine,
+		StartColumn: location.StartColumn,
+		EndColumn:   location.EndColumn,
+	}
+	if location.Snippet != "" {
+		region.Snippet = &sarifMultiFmtText{Text: location.Snippet}
+	}
+	physicalLocation.Region = region
+	return physicalLocation
+}
+
+func sarifLevelFor(s severity.Severity) string {
+	switch s {
+	case severity.High:
+		return "error"
+	case severity.Medium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func sarifRulesFor(r *DetectionResults) []sarifRule {
+	categories := []string{"filecontent", "filename", "filesize"}
+	rules := make([]sarifRule, 0, len(categories))
+	for _, category := range categories {
+		rules = append(rules, sarifRule{
+			ID:               category,
+			Name:             category,
+			ShortDescription: sarifMultiFmtText{Text: sarifRuleDescriptions[category]},
+		})
+	}
+	return rules
+}
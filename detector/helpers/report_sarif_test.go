@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"talisman/detector/severity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSarifLevelFor(t *testing.T) {
+	testCases := []struct {
+		severity severity.Severity
+		expected string
+	}{
+		{severity.High, "error"},
+		{severity.Medium, "warning"},
+		{severity.Low, "note"},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, sarifLevelFor(testCase.severity))
+	}
+}
+
+func TestWriteReportDispatchesOnFormat(t *testing.T) {
+	results := &DetectionResults{SchemaVersion: CurrentSchemaVersion, Results: []ResultsDetails{}}
+
+	assert.NoError(t, results.WriteReport(ioutil.Discard, ReportFormatSARIF, ReportOptions{}))
+	assert.Error(t, results.WriteReport(ioutil.Discard, ReportFormat("unknown"), ReportOptions{}))
+}